@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v2"
+)
+
+const DefaultConfigPath = "stdidx.yaml"
+
+// LoadConfig reads and validates a stdidx.yaml config describing one or more
+// standards sources to sync and merge.
+func LoadConfig(path string) (*StdidxConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config StdidxConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	if err := validate.Struct(config); err != nil {
+		return nil, err
+	}
+
+	for i := range config.Sources {
+		resolveAuthSecrets(&config.Sources[i].Auth)
+	}
+	return &config, nil
+}
+
+// resolveAuthSecrets reads any secrets auth's *Env fields point at out of
+// the environment, so credentials never have to live in stdidx.yaml itself.
+func resolveAuthSecrets(auth *GitAuth) {
+	if auth.TokenEnv != "" {
+		auth.Token = os.Getenv(auth.TokenEnv)
+	}
+	if auth.SSHKeyPassphraseEnv != "" {
+		auth.SSHKeyPassphrase = os.Getenv(auth.SSHKeyPassphraseEnv)
+	}
+}
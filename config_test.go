@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		t.Setenv("STDIDX_TEST_TOKEN", "s3cr3t")
+
+		path := filepath.Join(t.TempDir(), "stdidx.yaml")
+		content := `
+output: merged-tree.yaml
+sources:
+  - name: org
+    repository: https://example.com/org-standards.git
+    clone_path: .stdidx/org
+  - name: team
+    repository: https://example.com/team-standards.git
+    clone_path: .stdidx/team
+    cloner: go-git
+    auth:
+      type: http-token
+      username: x-access-token
+      http_token_env: STDIDX_TEST_TOKEN
+`
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		config, err := LoadConfig(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "merged-tree.yaml", config.Output)
+		assert.Len(t, config.Sources, 2)
+
+		team := config.Sources[1]
+		assert.Equal(t, "go-git", team.Cloner)
+		assert.Equal(t, AuthTypeHTTPAuth, team.Auth.Type)
+		assert.Equal(t, "s3cr3t", team.Auth.Token)
+	})
+
+	t.Run("missing required field", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "stdidx.yaml")
+		content := `
+sources:
+  - name: org
+    repository: https://example.com/org-standards.git
+    clone_path: .stdidx/org
+`
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		_, err := LoadConfig(path)
+		assert.Error(t, err)
+	})
+}
@@ -1,18 +1,82 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	log "github.com/sirupsen/logrus"
 )
 
 type GitCloner interface {
 	Clone(repo GitRepository) error
+
+	// Update brings an existing clone at repo.ClonePath up to date via a
+	// fetch and a hard reset, instead of removing and re-cloning it. Local
+	// changes in the working tree are discarded by the reset.
+	Update(repo GitRepository) error
+
+	// Head resolves the commit SHA currently checked out at clonePath.
+	Head(clonePath string) (string, error)
+}
+
+// isClonedRepository reports whether clonePath holds a git working tree
+// whose "origin" remote matches url, so Sync can fetch+reset in place
+// instead of removing and re-cloning.
+func isClonedRepository(clonePath, url string) bool {
+	repository, err := git.PlainOpen(clonePath)
+	if err != nil {
+		return false
+	}
+
+	remote, err := repository.Remote("origin")
+	if err != nil {
+		return false
+	}
+
+	cfg := remote.Config()
+	return cfg != nil && len(cfg.URLs) > 0 && cfg.URLs[0] == url
+}
+
+// NewGitCloner resolves the requested cloner backend name ("exec" or
+// "go-git") to a GitCloner implementation.
+func NewGitCloner(backend string) (GitCloner, error) {
+	switch backend {
+	case "", "exec":
+		return &ExecGitCloner{}, nil
+	case "go-git":
+		return &GoGitCloner{}, nil
+	default:
+		return nil, fmt.Errorf("unknown git cloner backend: %s", backend)
+	}
 }
 
 type ExecGitCloner struct{}
 
+// buildCloneArgs assembles the `git clone` arguments for repo.
+func buildCloneArgs(repo GitRepository) []string {
+	args := []string{"clone", repo.Repository, repo.ClonePath}
+	if repo.Branch != "" {
+		args = append(args, "--branch", repo.Branch)
+	}
+	if repo.Tag != "" {
+		args = append(args, "--tag", repo.Tag)
+	}
+	if repo.Depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", repo.Depth))
+	}
+	if repo.Filter != "" {
+		args = append(args, fmt.Sprintf("--filter=%s", repo.Filter))
+	}
+	return args
+}
+
 func (g *ExecGitCloner) Clone(repo GitRepository) error {
 	log.WithFields(log.Fields{
 		"url":    repo.Repository,
@@ -21,16 +85,247 @@ func (g *ExecGitCloner) Clone(repo GitRepository) error {
 		"tag":    repo.Tag,
 	}).Info("cloning git repository")
 
-	args := []string{"clone", repo.Repository, repo.ClonePath}
-	if repo.Branch != "" {
-		args = append(args, "--branch", repo.Branch)
+	cmd := exec.Command("git", buildCloneArgs(repo)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
 	}
-	if repo.Tag != "" {
-		args = append(args, "--tag", repo.Tag)
+
+	if repo.Commit != "" {
+		log.WithFields(log.Fields{
+			"path":   repo.ClonePath,
+			"commit": repo.Commit,
+		}).Info("pinning to commit")
+
+		checkout := exec.Command("git", "checkout", repo.Commit)
+		checkout.Dir = repo.ClonePath
+		checkout.Stdout = os.Stdout
+		checkout.Stderr = os.Stderr
+		return checkout.Run()
 	}
+	return nil
+}
 
-	cmd := exec.Command("git", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+// buildFetchArgs assembles the `git fetch` arguments for repo.
+func buildFetchArgs(repo GitRepository) []string {
+	args := []string{"-C", repo.ClonePath, "fetch", "origin"}
+	if repo.Depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", repo.Depth))
+	}
+	if repo.Filter != "" {
+		args = append(args, fmt.Sprintf("--filter=%s", repo.Filter))
+	}
+	return args
+}
+
+// resetRef determines the ref `git reset --hard` should target after a
+// fetch: the pinned commit if set, otherwise origin's branch or tag tip,
+// otherwise origin's default branch.
+func resetRef(repo GitRepository) string {
+	switch {
+	case repo.Commit != "":
+		return repo.Commit
+	case repo.Branch != "":
+		return "origin/" + repo.Branch
+	case repo.Tag != "":
+		return "tags/" + repo.Tag
+	default:
+		return "origin/HEAD"
+	}
+}
+
+func (g *ExecGitCloner) Update(repo GitRepository) error {
+	log.WithFields(log.Fields{
+		"path":   repo.ClonePath,
+		"branch": repo.Branch,
+		"tag":    repo.Tag,
+		"commit": repo.Commit,
+	}).Info("fetching git repository")
+
+	fetch := exec.Command("git", buildFetchArgs(repo)...)
+	fetch.Stdout = os.Stdout
+	fetch.Stderr = os.Stderr
+	if err := fetch.Run(); err != nil {
+		return err
+	}
+
+	reset := exec.Command("git", "-C", repo.ClonePath, "reset", "--hard", resetRef(repo))
+	reset.Stdout = os.Stdout
+	reset.Stderr = os.Stderr
+	return reset.Run()
+}
+
+func (g *ExecGitCloner) Head(clonePath string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = clonePath
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GoGitCloner clones standards repositories using the in-process go-git
+// implementation rather than shelling out to the git binary. This makes
+// private repositories reachable without relying on the host's git
+// configuration. Clones are always written to disk at repo.ClonePath. go-git
+// has no partial clone support, so it rejects a non-empty
+// GitRepository.Filter rather than silently cloning in full.
+type GoGitCloner struct{}
+
+func (g *GoGitCloner) Clone(repo GitRepository) error {
+	log.WithFields(log.Fields{
+		"url":      repo.Repository,
+		"path":     repo.ClonePath,
+		"branch":   repo.Branch,
+		"tag":      repo.Tag,
+		"authType": repo.Auth.Type,
+	}).Info("cloning git repository with go-git")
+
+	if repo.Filter != "" {
+		return fmt.Errorf("--cloner=go-git does not support --filter (partial clone); use --cloner=exec instead")
+	}
+
+	auth, err := buildAuthMethod(repo.Auth)
+	if err != nil {
+		log.WithError(err).Error("failed to configure git authentication")
+		return err
+	}
+
+	opts := &git.CloneOptions{
+		URL:      repo.Repository,
+		Auth:     auth,
+		Progress: os.Stdout,
+		Depth:    repo.Depth,
+	}
+	switch {
+	case repo.Branch != "":
+		opts.ReferenceName = plumbing.NewBranchReferenceName(repo.Branch)
+	case repo.Tag != "":
+		opts.ReferenceName = plumbing.NewTagReferenceName(repo.Tag)
+	}
+
+	repository, err := git.PlainClone(repo.ClonePath, false, opts)
+	if err != nil {
+		return err
+	}
+
+	if repo.Commit != "" {
+		log.WithFields(log.Fields{
+			"path":   repo.ClonePath,
+			"commit": repo.Commit,
+		}).Info("pinning to commit")
+
+		worktree, err := repository.Worktree()
+		if err != nil {
+			return err
+		}
+		return worktree.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(repo.Commit)})
+	}
+	return nil
+}
+
+func (g *GoGitCloner) Update(repo GitRepository) error {
+	log.WithFields(log.Fields{
+		"path":   repo.ClonePath,
+		"branch": repo.Branch,
+		"tag":    repo.Tag,
+		"commit": repo.Commit,
+	}).Info("fetching git repository with go-git")
+
+	if repo.Filter != "" {
+		return fmt.Errorf("--cloner=go-git does not support --filter (partial clone); use --cloner=exec instead")
+	}
+
+	repository, err := git.PlainOpen(repo.ClonePath)
+	if err != nil {
+		return err
+	}
+
+	auth, err := buildAuthMethod(repo.Auth)
+	if err != nil {
+		log.WithError(err).Error("failed to configure git authentication")
+		return err
+	}
+
+	err = repository.Fetch(&git.FetchOptions{
+		RemoteName: "origin",
+		Auth:       auth,
+		Depth:      repo.Depth,
+		Force:      true,
+		Progress:   os.Stdout,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return err
+	}
+
+	hash, err := resolveRemoteHash(repository, repo)
+	if err != nil {
+		return err
+	}
+
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return err
+	}
+	return worktree.Reset(&git.ResetOptions{Commit: hash, Mode: git.HardReset})
+}
+
+// resolveRemoteHash determines which commit repo should be reset to after a
+// fetch: the pinned commit if set, otherwise the tip of origin's branch or
+// tag, otherwise origin's default branch.
+func resolveRemoteHash(repository *git.Repository, repo GitRepository) (plumbing.Hash, error) {
+	if repo.Commit != "" {
+		return plumbing.NewHash(repo.Commit), nil
+	}
+
+	var refName plumbing.ReferenceName
+	switch {
+	case repo.Branch != "":
+		refName = plumbing.NewRemoteReferenceName("origin", repo.Branch)
+	case repo.Tag != "":
+		refName = plumbing.NewTagReferenceName(repo.Tag)
+	default:
+		refName = plumbing.NewRemoteHEADReferenceName("origin")
+	}
+
+	ref, err := repository.Reference(refName, true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return ref.Hash(), nil
+}
+
+func (g *GoGitCloner) Head(clonePath string) (string, error) {
+	repository, err := git.PlainOpen(clonePath)
+	if err != nil {
+		return "", err
+	}
+	ref, err := repository.Head()
+	if err != nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
+}
+
+// buildAuthMethod translates a GitAuth into the transport.AuthMethod go-git
+// expects, returning a nil method for public, unauthenticated clones.
+func buildAuthMethod(auth GitAuth) (transport.AuthMethod, error) {
+	switch auth.Type {
+	case "", AuthTypeNone:
+		return nil, nil
+	case AuthTypeHTTPAuth:
+		username := auth.Username
+		if username == "" {
+			username = "git"
+		}
+		return &http.BasicAuth{Username: username, Password: auth.Token}, nil
+	case AuthTypeSSHKey:
+		return ssh.NewPublicKeysFromFile("git", auth.SSHKeyPath, auth.SSHKeyPassphrase)
+	case AuthTypeSSHAgent:
+		return ssh.NewSSHAgentAuth("git")
+	default:
+		return nil, fmt.Errorf("unsupported auth type: %s", auth.Type)
+	}
 }
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"testing"
+
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 )
 
 type MockGitCloner struct {
@@ -17,8 +20,66 @@ func (m *MockGitCloner) Clone(repo GitRepository) error {
 	return copyDir(m.SourceDir, repo.ClonePath)
 }
 
+func (m *MockGitCloner) Update(repo GitRepository) error {
+	return copyDir(m.SourceDir, repo.ClonePath)
+}
+
+func (m *MockGitCloner) Head(clonePath string) (string, error) {
+	return "0000000000000000000000000000000000000000", nil
+}
+
 func NewMockGitCloner(dir string) *MockGitCloner {
 	return &MockGitCloner{
 		SourceDir: dir,
 	}
 }
+
+func TestBuildCloneArgs(t *testing.T) {
+	t.Run("plain clone", func(t *testing.T) {
+		repo := GitRepository{Repository: "https://example.com/standards.git", ClonePath: ".stdidx"}
+		args := buildCloneArgs(repo)
+		assert.Equal(t, []string{"clone", "https://example.com/standards.git", ".stdidx"}, args)
+	})
+
+	t.Run("shallow and partial clone", func(t *testing.T) {
+		repo := GitRepository{
+			Repository: "https://example.com/standards.git",
+			ClonePath:  ".stdidx",
+			Branch:     "main",
+			Depth:      1,
+			Filter:     "blob:none",
+		}
+		args := buildCloneArgs(repo)
+		assert.Equal(t, []string{
+			"clone", "https://example.com/standards.git", ".stdidx",
+			"--branch", "main",
+			"--depth=1",
+			"--filter=blob:none",
+		}, args)
+	})
+}
+
+func TestBuildFetchArgs(t *testing.T) {
+	repo := GitRepository{ClonePath: ".stdidx", Depth: 1, Filter: "tree:0"}
+	args := buildFetchArgs(repo)
+	assert.Equal(t, []string{"-C", ".stdidx", "fetch", "origin", "--depth=1", "--filter=tree:0"}, args)
+}
+
+func TestResetRef(t *testing.T) {
+	t.Run("commit takes precedence", func(t *testing.T) {
+		repo := GitRepository{Branch: "main", Commit: "abc123"}
+		assert.Equal(t, "abc123", resetRef(repo))
+	})
+
+	t.Run("branch", func(t *testing.T) {
+		assert.Equal(t, "origin/main", resetRef(GitRepository{Branch: "main"}))
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		assert.Equal(t, "tags/v1.0.0", resetRef(GitRepository{Tag: "v1.0.0"}))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, "origin/HEAD", resetRef(GitRepository{}))
+	})
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+const StdidxIgnoreFile = ".stdidxignore"
+
+// attributeRule is a single line parsed out of a .gitattributes file that
+// carries one of the stdidx-specific attributes.
+type attributeRule struct {
+	pattern string
+	ignore  bool
+	scope   string
+}
+
+// loadIgnoreMatcher builds a gitignore.Matcher from every .gitignore
+// encountered under root plus the top-level .stdidxignore, so discovery can
+// skip vendored or generated markdown the same way git itself would.
+func loadIgnoreMatcher(root string) (gitignore.Matcher, error) {
+	var patterns []gitignore.Pattern
+
+	if rootPatterns, err := readIgnoreFile(filepath.Join(root, StdidxIgnoreFile), nil); err != nil {
+		return nil, err
+	} else {
+		patterns = append(patterns, rootPatterns...)
+	}
+
+	err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		dirPatterns, err := readIgnoreFile(filepath.Join(p, ".gitignore"), dirParts(root, p))
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, dirPatterns...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return gitignore.NewMatcher(patterns), nil
+}
+
+// readIgnoreFile parses a single gitignore-format file, scoping its patterns
+// to path (the directory the file lives in, relative to the walk root). A
+// missing file yields no patterns and no error.
+func readIgnoreFile(file string, path []string) ([]gitignore.Pattern, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var patterns []gitignore.Pattern
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, path))
+	}
+	return patterns, nil
+}
+
+// dirParts returns dir's path components relative to root, as expected by
+// gitignore.ParsePattern, or nil for root itself.
+func dirParts(root, dir string) []string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." {
+		return nil
+	}
+	return strings.Split(rel, string(filepath.Separator))
+}
+
+// isIgnored reports whether p (relative to root) matches the ignore
+// matcher.
+func isIgnored(matcher gitignore.Matcher, root, p string, isDir bool) bool {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return false
+	}
+	return matcher.Match(strings.Split(rel, string(filepath.Separator)), isDir)
+}
+
+// loadAttributeRules parses the top-level .gitattributes file for the
+// stdidx-ignore and stdidx-scope=<glob> attributes.
+func loadAttributeRules(root string) ([]attributeRule, error) {
+	content, err := os.ReadFile(filepath.Join(root, ".gitattributes"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rules []attributeRule
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		rule := attributeRule{pattern: fields[0]}
+		for _, attr := range fields[1:] {
+			switch {
+			case attr == "stdidx-ignore":
+				rule.ignore = true
+			case strings.HasPrefix(attr, "stdidx-scope="):
+				rule.scope = strings.TrimPrefix(attr, "stdidx-scope=")
+			}
+		}
+		if rule.ignore || rule.scope != "" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// matchAttributes reports whether p (relative to root) is marked
+// stdidx-ignore by any rule, and returns the stdidx-scope of the last rule
+// that set one.
+func matchAttributes(rules []attributeRule, root, p string) (ignore bool, scope string) {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return false, ""
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, rule := range rules {
+		matched, _ := path.Match(rule.pattern, rel)
+		if !matched {
+			matched, _ = path.Match(rule.pattern, path.Base(rel))
+		}
+		if !matched {
+			continue
+		}
+		if rule.ignore {
+			ignore = true
+		}
+		if rule.scope != "" {
+			scope = rule.scope
+		}
+	}
+	return ignore, scope
+}
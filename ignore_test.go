@@ -0,0 +1,81 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeFixture writes content to root/relPath, creating parent directories
+// as needed. See writeMDFixture in tree_test.go for the same helper taking
+// an already-joined path.
+func writeFixture(t *testing.T, root, relPath, content string) {
+	t.Helper()
+	writeMDFixture(t, filepath.Join(root, relPath), content)
+}
+
+func TestParseMDDocumentsHonorsIgnoreRules(t *testing.T) {
+	root := t.TempDir()
+
+	writeFixture(t, root, "GENERAL.md", `---
+title: General Standards
+description: Baseline standards for the repo.
+scope: "*"
+topics: ["general"]
+---
+`)
+
+	writeFixture(t, root, "vendor/THIRDPARTY.md", `---
+title: Vendored Docs
+description: Should never be indexed.
+scope: "*"
+topics: ["vendor"]
+---
+`)
+	writeFixture(t, root, ".gitignore", "vendor/\n")
+
+	writeFixture(t, root, "secret/SECRET.md", `---
+title: Internal Only
+description: Should never be indexed.
+scope: "*"
+topics: ["internal"]
+---
+`)
+	writeFixture(t, root, ".stdidxignore", "secret/\n")
+
+	writeFixture(t, root, "ignored-by-attr.md", `---
+title: Attribute Ignored
+description: Excluded via a stdidx-ignore gitattribute.
+scope: "*"
+topics: ["general"]
+---
+`)
+	writeFixture(t, root, "noscope/NOSCOPE.md", `---
+title: No Scope Doc
+description: A doc without an explicit scope in its frontmatter.
+topics: ["python"]
+---
+`)
+	writeFixture(t, root, ".gitattributes", ""+
+		"ignored-by-attr.md stdidx-ignore\n"+
+		"noscope/NOSCOPE.md stdidx-scope=*.py\n",
+	)
+
+	files, err := ParseMDDocuments(root)
+	assert.NoError(t, err)
+
+	paths := make(map[string]StandardsFile, len(files))
+	for _, f := range files {
+		paths[f.Path] = f
+	}
+
+	assert.Contains(t, paths, filepath.Join(root, "GENERAL.md"))
+	assert.NotContains(t, paths, filepath.Join(root, "vendor/THIRDPARTY.md"))
+	assert.NotContains(t, paths, filepath.Join(root, "secret/SECRET.md"))
+	assert.NotContains(t, paths, filepath.Join(root, "ignored-by-attr.md"))
+
+	noscope, ok := paths[filepath.Join(root, "noscope/NOSCOPE.md")]
+	assert.True(t, ok)
+	assert.Equal(t, "*.py", noscope.Header.Scope)
+}
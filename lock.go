@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const LockfilePath = ".stdidx.lock"
+
+// WriteLockfile persists the resolved repository/ref/commit so that
+// subsequent syncs can reproduce the exact revision without --commit.
+func WriteLockfile(path string, lock Lockfile) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadLockfile loads a previously written lockfile. It returns a nil
+// Lockfile, with no error, if the file does not exist.
+func ReadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var lock Lockfile
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		log.WithError(err).Error("failed to parse lockfile")
+		return nil, err
+	}
+	return &lock, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockfileRoundTrip(t *testing.T) {
+	t.Run("write then read", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".stdidx.lock")
+
+		lock := Lockfile{
+			Repository: "https://example.com/standards.git",
+			Ref:        "main",
+			Commit:     "abc123",
+		}
+		assert.NoError(t, WriteLockfile(path, lock))
+
+		read, err := ReadLockfile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, &lock, read)
+	})
+
+	t.Run("missing file returns nil, no error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".stdidx.lock")
+
+		lock, err := ReadLockfile(path)
+		assert.NoError(t, err)
+		assert.Nil(t, lock)
+	})
+}
@@ -14,14 +14,54 @@ func PrintSuggestedInstructions() {
 	println("\n" + SuggestedAgentInstructions + "\n")
 }
 
-func Sync(ctx context.Context, cloner GitCloner, repository GitRepository) error {
+// resolveSyncCommit validates the --branch/--tag/--commit flags and, if the
+// user requested none of a branch, tag or commit and did not pass --update,
+// falls back to the commit
+// pinned in the lockfile at lockfilePath — but only when that lockfile was
+// written for this same repository, so a stale or unrelated lockfile is
+// never silently applied.
+func resolveSyncCommit(lockfilePath, repository, branch, tag, commit string, update bool) (string, error) {
+	if branch != "" && tag != "" {
+		return "", errors.New("only one of --branch or --tag can be specified, not both")
+	}
+	if commit != "" && (branch != "" || tag != "") {
+		return "", errors.New("--commit cannot be combined with --branch or --tag")
+	}
+
+	if commit != "" || branch != "" || tag != "" || update {
+		return commit, nil
+	}
+
+	lock, err := ReadLockfile(lockfilePath)
+	if err != nil {
+		return "", err
+	}
+	if lock == nil || lock.Repository != repository {
+		return "", nil
+	}
+
 	log.WithFields(log.Fields{
-		"repository": repository.Repository,
-		"branch":     repository.Branch,
-		"tag":        repository.Tag,
-	}).Info("syncing standards library")
+		"commit": lock.Commit,
+	}).Info("honoring commit pinned in lockfile")
+	return lock.Commit, nil
+}
+
+// cloneRepository brings repository.ClonePath up to date. If it is already
+// a clone of the same repository, it is updated in place via cloner.Update;
+// otherwise any stale directory is removed and cloner.Clone starts fresh.
+func cloneRepository(cloner GitCloner, repository GitRepository) error {
+	if isClonedRepository(repository.ClonePath, repository.Repository) {
+		log.WithFields(log.Fields{
+			"clone_path": repository.ClonePath,
+		}).Info("found existing clone, updating in place")
+
+		if err := cloner.Update(repository); err != nil {
+			log.WithError(err).Error("failed to update standards repository")
+			return err
+		}
+		return nil
+	}
 
-	// check if already exists
 	if _, err := os.Stat(repository.ClonePath); err == nil {
 		log.WithFields(log.Fields{
 			"clone_path": repository.ClonePath,
@@ -36,6 +76,46 @@ func Sync(ctx context.Context, cloner GitCloner, repository GitRepository) error
 		log.WithError(err).Error("failed to clone standards repository")
 		return err
 	}
+	return nil
+}
+
+func Sync(ctx context.Context, cloner GitCloner, repository GitRepository) error {
+	log.WithFields(log.Fields{
+		"repository": repository.Repository,
+		"branch":     repository.Branch,
+		"tag":        repository.Tag,
+	}).Info("syncing standards library")
+
+	if err := cloneRepository(cloner, repository); err != nil {
+		return err
+	}
+
+	sha, err := cloner.Head(repository.ClonePath)
+	if err != nil {
+		log.WithError(err).Error("failed to resolve checked-out commit")
+		return err
+	}
+
+	ref := repository.Commit
+	switch {
+	case ref != "":
+	case repository.Branch != "":
+		ref = repository.Branch
+	case repository.Tag != "":
+		ref = repository.Tag
+	default:
+		ref = "HEAD"
+	}
+
+	lock := Lockfile{
+		Repository: repository.Repository,
+		Ref:        ref,
+		Commit:     sha,
+	}
+	if err := WriteLockfile(LockfilePath, lock); err != nil {
+		log.WithError(err).Error("failed to write lockfile")
+		return err
+	}
 
 	log.WithFields(log.Fields{
 		"clone_path": repository.ClonePath,
@@ -64,6 +144,41 @@ func Index(ctx context.Context, clonePath string) error {
 	return nil
 }
 
+// SyncAll clones every standards source described by config and merges
+// their headers into a single namespaced tree at config.Output.
+func SyncAll(ctx context.Context, config *StdidxConfig) error {
+	log.WithFields(log.Fields{
+		"sources": len(config.Sources),
+		"output":  config.Output,
+	}).Info("syncing standards libraries")
+
+	for _, source := range config.Sources {
+		log.WithFields(log.Fields{
+			"source":     source.Name,
+			"repository": source.Repository,
+			"cloner":     source.Cloner,
+		}).Info("syncing standards source")
+
+		cloner, err := NewGitCloner(source.Cloner)
+		if err != nil {
+			return err
+		}
+
+		if err := cloneRepository(cloner, source.GitRepository); err != nil {
+			return err
+		}
+	}
+
+	log.Info("generating merged standards index")
+	if err := GenerateMergedStandardsTree(config.Sources, config.Output); err != nil {
+		log.WithError(err).Error("failed to generate merged standards index")
+		return err
+	}
+
+	log.Info("successfully synced standards libraries")
+	return nil
+}
+
 func main() {
 	cli := &cli.Command{
 		Name:  "std-index",
@@ -89,22 +204,79 @@ func main() {
 						Aliases: []string{"t"},
 						Usage:   "Tag to checkout",
 					},
+					&cli.StringFlag{
+						Name:  "cloner",
+						Usage: "Git cloner backend to use (exec|go-git)",
+						Value: "exec",
+					},
+					&cli.StringFlag{
+						Name:  "auth-type",
+						Usage: "Authentication scheme to use with --cloner=go-git (none|http-token|ssh-key|ssh-agent)",
+						Value: string(AuthTypeNone),
+					},
+					&cli.StringFlag{
+						Name:  "ssh-key",
+						Usage: "Path to an SSH private key, used when --auth-type=ssh-key",
+					},
+					&cli.StringFlag{
+						Name:  "ssh-key-passphrase-env",
+						Usage: "Name of the environment variable holding the SSH key passphrase, used when --auth-type=ssh-key",
+					},
+					&cli.StringFlag{
+						Name:  "http-token-env",
+						Usage: "Name of the environment variable holding an HTTPS access token, used when --auth-type=http-token",
+					},
+					&cli.IntFlag{
+						Name:  "depth",
+						Usage: "Create a shallow clone with a history truncated to the given number of commits",
+					},
+					&cli.StringFlag{
+						Name:  "filter",
+						Usage: "Clone filter, e.g. 'blob:none' or 'tree:0', to speed up large standards repos (only supported with --cloner=exec)",
+					},
+					&cli.StringFlag{
+						Name:  "commit",
+						Usage: "Pin the clone to an exact commit SHA (mutually exclusive with --branch/--tag)",
+					},
+					&cli.BoolFlag{
+						Name:  "update",
+						Usage: "Ignore any existing lockfile and re-resolve --branch/--tag/--commit",
+					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
 					branch := cmd.String("branch")
 					tag := cmd.String("tag")
+					repository := cmd.String("repository")
+
+					commit, err := resolveSyncCommit(LockfilePath, repository, branch, tag, cmd.String("commit"), cmd.Bool("update"))
+					if err != nil {
+						return err
+					}
 
-					if branch != "" && tag != "" {
-						return errors.New("only one of --branch or --tag can be specified, not both")
+					auth := GitAuth{Type: AuthType(cmd.String("auth-type"))}
+					auth.SSHKeyPath = cmd.String("ssh-key")
+					if env := cmd.String("ssh-key-passphrase-env"); env != "" {
+						auth.SSHKeyPassphrase = os.Getenv(env)
+					}
+					if env := cmd.String("http-token-env"); env != "" {
+						auth.Token = os.Getenv(env)
 					}
 
 					repo := GitRepository{
-						Repository: cmd.String("repository"),
+						Repository: repository,
 						Branch:     branch,
 						Tag:        tag,
 						ClonePath:  DefaultClonePath,
+						Auth:       auth,
+						Depth:      int(cmd.Int("depth")),
+						Filter:     cmd.String("filter"),
+						Commit:     commit,
+					}
+
+					cloner, err := NewGitCloner(cmd.String("cloner"))
+					if err != nil {
+						return err
 					}
-					cloner := &ExecGitCloner{}
 					if err := Sync(ctx, cloner, repo); err != nil {
 						return err
 					}
@@ -112,6 +284,28 @@ func main() {
 					return nil
 				},
 			},
+			{
+				Name:  "sync-all",
+				Usage: "Sync and merge multiple standards sources described by a stdidx.yaml config",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "config",
+						Usage: "Path to the stdidx.yaml config listing standards sources",
+						Value: DefaultConfigPath,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					config, err := LoadConfig(cmd.String("config"))
+					if err != nil {
+						return err
+					}
+					if err := SyncAll(ctx, config); err != nil {
+						return err
+					}
+					PrintSuggestedInstructions()
+					return nil
+				},
+			},
 			{
 				Name:  "index",
 				Usage: "Index a standards library",
@@ -24,6 +24,63 @@ func init() {
 	log.SetLevel(log.DebugLevel)
 }
 
+func TestResolveSyncCommit(t *testing.T) {
+	t.Run("rejects branch and tag together", func(t *testing.T) {
+		_, err := resolveSyncCommit(filepath.Join(t.TempDir(), ".stdidx.lock"), "repo", "main", "v1", "", false)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects commit combined with branch", func(t *testing.T) {
+		_, err := resolveSyncCommit(filepath.Join(t.TempDir(), ".stdidx.lock"), "repo", "main", "", "abc123", false)
+		assert.Error(t, err)
+	})
+
+	t.Run("explicit commit wins, lockfile not consulted", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".stdidx.lock")
+		assert.NoError(t, WriteLockfile(path, Lockfile{Repository: "repo", Commit: "stale"}))
+
+		commit, err := resolveSyncCommit(path, "repo", "", "", "fresh", false)
+		assert.NoError(t, err)
+		assert.Equal(t, "fresh", commit)
+	})
+
+	t.Run("explicit branch is not overridden by a stale lockfile commit", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".stdidx.lock")
+		assert.NoError(t, WriteLockfile(path, Lockfile{Repository: "repo", Commit: "stale"}))
+
+		commit, err := resolveSyncCommit(path, "repo", "develop", "", "", false)
+		assert.NoError(t, err)
+		assert.Empty(t, commit)
+	})
+
+	t.Run("lockfile for a different repository is ignored", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".stdidx.lock")
+		assert.NoError(t, WriteLockfile(path, Lockfile{Repository: "other-repo", Commit: "stale"}))
+
+		commit, err := resolveSyncCommit(path, "repo", "", "", "", false)
+		assert.NoError(t, err)
+		assert.Empty(t, commit)
+	})
+
+	t.Run("matching lockfile commit is honored", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".stdidx.lock")
+		assert.NoError(t, WriteLockfile(path, Lockfile{Repository: "repo", Commit: "pinned"}))
+
+		commit, err := resolveSyncCommit(path, "repo", "", "", "", false)
+		assert.NoError(t, err)
+		assert.Equal(t, "pinned", commit)
+	})
+
+	t.Run("--update ignores the lockfile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".stdidx.lock")
+		assert.NoError(t, WriteLockfile(path, Lockfile{Repository: "repo", Commit: "pinned"}))
+
+		commit, err := resolveSyncCommit(path, "repo", "", "", "", true)
+		assert.NoError(t, err)
+		assert.Empty(t, commit)
+	})
+}
+
 func TestSync(t *testing.T) {
 	t.Run("success - existing directory", func(t *testing.T) {
 		ctx := context.Background()
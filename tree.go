@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
@@ -13,11 +14,9 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-func ExtractMDHeader(path string) (*StandardsHeader, error) {
-	log.WithFields(log.Fields{
-		"path": path,
-	}).Debug("extracting md header")
-
+// parseMDFrontmatter reads path and decodes its frontmatter, without
+// validating the result.
+func parseMDFrontmatter(path string) (*StandardsHeader, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -28,23 +27,69 @@ func ExtractMDHeader(path string) (*StandardsHeader, error) {
 	if _, err := frontmatter.Parse(reader, &header); err != nil {
 		return nil, err
 	}
+	return &header, nil
+}
 
+// validMDHeader reports whether header carries every required field.
+func validMDHeader(header *StandardsHeader) bool {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 	if err := validate.Struct(header); err != nil {
 		log.WithError(err).Debug("failed to validate md header")
+		return false
+	}
+	return true
+}
+
+func ExtractMDHeader(path string) (*StandardsHeader, error) {
+	log.WithFields(log.Fields{
+		"path": path,
+	}).Debug("extracting md header")
+
+	header, err := parseMDFrontmatter(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validMDHeader(header) {
 		return nil, nil
 	}
-	return &header, nil
+	return header, nil
 }
 
 func ParseMDDocuments(root string) ([]StandardsFile, error) {
 	headers := make([]StandardsFile, 0)
 
-	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+	ignoreMatcher, err := loadIgnoreMatcher(root)
+	if err != nil {
+		return nil, err
+	}
+
+	attributeRules, err := loadAttributeRules(root)
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
+
 		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if isIgnored(ignoreMatcher, root, p, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if isIgnored(ignoreMatcher, root, p, false) {
+			return nil
+		}
+
+		ignoredByAttr, scope := matchAttributes(attributeRules, root, p)
+		if ignoredByAttr {
 			return nil
 		}
 
@@ -53,19 +98,23 @@ func ParseMDDocuments(root string) ([]StandardsFile, error) {
 		}
 
 		// read contents of file and parse frontmatter.
-		header, err := ExtractMDHeader(path)
+		header, err := parseMDFrontmatter(p)
 		if err != nil {
 			return err
 		}
 
-		if header != nil {
+		if header.Scope == "" && scope != "" {
+			header.Scope = scope
+		}
+
+		if validMDHeader(header) {
 			headers = append(headers, StandardsFile{
-				Path:   path,
+				Path:   p,
 				Header: *header,
 			})
 		} else {
 			log.WithFields(log.Fields{
-				"path": path,
+				"path": p,
 			}).Warn("found markdown file without valid header. skipping.")
 		}
 
@@ -77,7 +126,7 @@ func ParseMDDocuments(root string) ([]StandardsFile, error) {
 	// relative to the directory the code is cloned into,
 	// but the tree is built from the root of the repository.
 	for i, file := range headers {
-		if file.Header.Parent != nil {
+		if file.Header.Parent != nil && !strings.HasPrefix(*file.Header.Parent, "source:") {
 			augmentedPath := path.Join(root, *file.Header.Parent)
 			headers[i].Header.Parent = &augmentedPath
 		}
@@ -85,6 +134,76 @@ func ParseMDDocuments(root string) ([]StandardsFile, error) {
 	return headers, err
 }
 
+// namespacedPath prefixes a path with its source name, so that identically
+// named files in different sources (e.g. two repos each with a GENERAL.md)
+// don't collide as BuildHierarchy node keys.
+func namespacedPath(source, p string) string {
+	return source + ":" + p
+}
+
+// resolveCrossSourceParent resolves a "source:<name>/<path>" parent
+// reference to the namespaced path of the node it points to.
+func resolveCrossSourceParent(parent string, sources map[string]GitRepository) (string, error) {
+	ref := strings.TrimPrefix(parent, "source:")
+	name, rel, found := strings.Cut(ref, "/")
+	if !found {
+		return "", fmt.Errorf("malformed cross-source parent reference: %s", parent)
+	}
+
+	source, exists := sources[name]
+	if !exists {
+		return "", fmt.Errorf("cross-source parent references unknown source %q: %s", name, parent)
+	}
+	return namespacedPath(name, path.Join(source.ClonePath, rel)), nil
+}
+
+// MergeSources parses every configured standards source and namespaces its
+// nodes so they can be merged into a single StandardsTree without parent
+// path collisions. Parents written as "source:<name>/path" are resolved
+// against the other sources' namespaces; all other parents are assumed to
+// be relative to the source that declares them.
+func MergeSources(sources []StandardsSource) ([]StandardsFile, error) {
+	repositories := make(map[string]GitRepository, len(sources))
+	for _, source := range sources {
+		repositories[source.Name] = source.GitRepository
+	}
+
+	merged := make([]StandardsFile, 0)
+	for _, source := range sources {
+		files, err := ParseMDDocuments(source.ClonePath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			file.Path = namespacedPath(source.Name, file.Path)
+
+			if file.Header.Parent != nil {
+				parent := *file.Header.Parent
+
+				var resolved string
+				if strings.HasPrefix(parent, "source:") {
+					resolved, err = resolveCrossSourceParent(parent, repositories)
+					if err != nil {
+						log.WithError(err).WithFields(log.Fields{
+							"source": source.Name,
+							"path":   file.Path,
+						}).Warn("failed to resolve cross-source parent. skipping reference.")
+						file.Header.Parent = nil
+						merged = append(merged, file)
+						continue
+					}
+				} else {
+					resolved = namespacedPath(source.Name, parent)
+				}
+				file.Header.Parent = &resolved
+			}
+			merged = append(merged, file)
+		}
+	}
+	return merged, nil
+}
+
 // BuildHierarchy builds a nested tree from a flat list of headers. Headers
 // without a Parent are root nodes. Headers with a Parent are nested under the
 // node whose Scope matches the parent value.
@@ -163,3 +282,28 @@ func GenerateStandardsTree(path string) error {
 	}
 	return nil
 }
+
+// GenerateMergedStandardsTree parses every configured standards source,
+// merges them into a single namespaced StandardsTree, and writes it to
+// outputPath.
+func GenerateMergedStandardsTree(sources []StandardsSource, outputPath string) error {
+	log.WithFields(log.Fields{
+		"sources": len(sources),
+	}).Debug("parsing standards files from all sources")
+
+	headers, err := MergeSources(sources)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"count": len(headers),
+	}).Debug("creating merged standards tree")
+
+	tree := BuildHierarchy(headers)
+
+	data, err := yaml.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, data, 0644)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -10,6 +11,14 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// writeMDFixture writes a markdown file with the given frontmatter body at
+// path, creating parent directories as needed.
+func writeMDFixture(t *testing.T, path, content string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
 func init() {
 	log.SetLevel(log.DebugLevel)
 }
@@ -137,3 +146,66 @@ func TestBuildHierarchy(t *testing.T) {
 		assert.Equal(t, string(encoded), string(content))
 	})
 }
+
+func TestMergeSources(t *testing.T) {
+	t.Run("namespaces nodes and resolves cross-source parents", func(t *testing.T) {
+		orgDir := t.TempDir()
+		teamDir := t.TempDir()
+
+		writeMDFixture(t, filepath.Join(orgDir, "GENERAL.md"), `---
+title: Org General Standards
+description: Cross-team baseline standards.
+scope: "*"
+topics: ["general"]
+---
+`)
+		writeMDFixture(t, filepath.Join(teamDir, "GENERAL.md"), `---
+title: Team General Standards
+description: Team-specific overlay.
+scope: "*"
+topics: ["team"]
+parent: "source:org/GENERAL.md"
+---
+`)
+
+		sources := []StandardsSource{
+			{Name: "org", GitRepository: GitRepository{ClonePath: orgDir}},
+			{Name: "team", GitRepository: GitRepository{ClonePath: teamDir}},
+		}
+
+		files, err := MergeSources(sources)
+		assert.NoError(t, err)
+		assert.Len(t, files, 2)
+
+		for _, file := range files {
+			assert.True(t, strings.HasPrefix(file.Path, "org:") || strings.HasPrefix(file.Path, "team:"))
+		}
+
+		tree := BuildHierarchy(files)
+		assert.Equal(t, 1, len(tree.Nodes))
+		assert.Equal(t, "Org General Standards", tree.Nodes[0].Title)
+		assert.Equal(t, 1, len(tree.Nodes[0].Children))
+		assert.Equal(t, "Team General Standards", tree.Nodes[0].Children[0].Title)
+	})
+
+	t.Run("unresolvable cross-source parent is dropped, not fatal", func(t *testing.T) {
+		teamDir := t.TempDir()
+		writeMDFixture(t, filepath.Join(teamDir, "GENERAL.md"), `---
+title: Team General Standards
+description: Team-specific overlay.
+scope: "*"
+topics: ["team"]
+parent: "source:missing/GENERAL.md"
+---
+`)
+
+		sources := []StandardsSource{
+			{Name: "team", GitRepository: GitRepository{ClonePath: teamDir}},
+		}
+
+		files, err := MergeSources(sources)
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		assert.Nil(t, files[0].Header.Parent)
+	})
+}
@@ -28,8 +28,90 @@ type StandardsTree struct {
 }
 
 type GitRepository struct {
-	Repository string `yaml:"repository" validate:"required"`
-	Branch     string `yaml:"branch" validate:"omitempty"`
-	Tag        string `yaml:"tag" validate:"omitempty"`
-	ClonePath  string `yaml:"clone_path" validate:"required"`
+	Repository string  `yaml:"repository" validate:"required"`
+	Branch     string  `yaml:"branch" validate:"omitempty"`
+	Tag        string  `yaml:"tag" validate:"omitempty"`
+	ClonePath  string  `yaml:"clone_path" validate:"required"`
+	Auth       GitAuth `yaml:"auth" validate:"omitempty"`
+
+	// Depth requests a shallow clone with the given commit history depth.
+	// Zero means a full clone.
+	Depth int `yaml:"depth" validate:"omitempty"`
+
+	// Filter requests a partial clone, e.g. "blob:none" or "tree:0". Since
+	// GenerateStandardsTree only reads *.md files, a treeless or blobless
+	// clone is sufficient for indexing and drastically cuts clone size.
+	// Only the "exec" cloner backend supports this; go-git has no partial
+	// clone support, so GoGitCloner rejects a non-empty Filter.
+	Filter string `yaml:"filter" validate:"omitempty"`
+
+	// Commit pins the clone to an exact revision, taking precedence over
+	// Branch and Tag once the repository is checked out.
+	Commit string `yaml:"commit" validate:"omitempty"`
+}
+
+// Lockfile records the exact revision a sync resolved to, so that
+// subsequent syncs can reproduce it without re-specifying --commit.
+type Lockfile struct {
+	Repository string `yaml:"repository"`
+	Ref        string `yaml:"ref"`
+	Commit     string `yaml:"commit"`
+}
+
+// AuthType identifies which credential scheme a GitCloner should use when
+// cloning a GitRepository.
+type AuthType string
+
+const (
+	AuthTypeNone     AuthType = "none"
+	AuthTypeHTTPAuth AuthType = "http-token"
+	AuthTypeSSHKey   AuthType = "ssh-key"
+	AuthTypeSSHAgent AuthType = "ssh-agent"
+)
+
+// GitAuth describes the credentials to use when cloning a private standards
+// repository. Type selects which of the remaining fields are consulted.
+// Secrets themselves are never read from YAML directly (Token and
+// SSHKeyPassphrase are left unexported from config files); instead the
+// *Env fields name an environment variable to read the secret from, the
+// same indirection the `sync` command's --http-token-env/
+// --ssh-key-passphrase-env flags use.
+type GitAuth struct {
+	Type AuthType `yaml:"type" validate:"omitempty"`
+
+	// Username and Token are used when Type is AuthTypeHTTPAuth. TokenEnv
+	// names the environment variable Token is resolved from when loading a
+	// stdidx.yaml config.
+	Username string `yaml:"username" validate:"omitempty"`
+	TokenEnv string `yaml:"http_token_env" validate:"omitempty"`
+	Token    string `yaml:"-"`
+
+	// SSHKeyPath and SSHKeyPassphrase are used when Type is AuthTypeSSHKey.
+	// SSHKeyPassphraseEnv names the environment variable SSHKeyPassphrase
+	// is resolved from when loading a stdidx.yaml config.
+	SSHKeyPath          string `yaml:"ssh_key_path" validate:"omitempty"`
+	SSHKeyPassphraseEnv string `yaml:"ssh_key_passphrase_env" validate:"omitempty"`
+	SSHKeyPassphrase    string `yaml:"-"`
+}
+
+// StandardsSource names a GitRepository so its nodes can be namespaced when
+// merged with other sources. The name is also how other sources reference
+// nodes owned by it, via a "source:<name>/path" parent.
+type StandardsSource struct {
+	Name string `yaml:"name" validate:"required"`
+
+	// Cloner selects the GitCloner backend used for this source
+	// ("exec"|"go-git"). Only the "go-git" backend honors Auth, so sources
+	// that need authenticated access must opt into it here. Defaults to
+	// "exec" when empty.
+	Cloner        string `yaml:"cloner" validate:"omitempty"`
+	GitRepository `yaml:",inline"`
+}
+
+// StdidxConfig is the stdidx.yaml format consumed by `std-index sync-all`.
+// It lists every standards source to clone and index, plus the path the
+// merged tree should be written to.
+type StdidxConfig struct {
+	Output  string            `yaml:"output" validate:"required"`
+	Sources []StandardsSource `yaml:"sources" validate:"required,dive"`
 }